@@ -0,0 +1,261 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultDataKeys are the Secret data keys inspected when Spec.DataKeys is empty.
+var DefaultDataKeys = []string{"tls.crt", "ca.crt"}
+
+// DefaultSecretTypes are the Secret types matched by a SourceSelector when its own
+// SecretTypes is empty.
+var DefaultSecretTypes = []corev1.SecretType{corev1.SecretTypeTLS}
+
+// DefaultExpiryWarningDays is used when Spec.ExpiryWarningDays is unset or zero.
+const DefaultExpiryWarningDays int32 = 30
+
+// Condition types reported on TLSSecretWatcher.Status.Conditions.
+const (
+	// ConditionReady indicates the trust bundle was reconciled into every Destination.
+	ConditionReady = "Ready"
+	// ConditionDegraded indicates the last reconciliation failed to write one or more
+	// Destinations.
+	ConditionDegraded = "Degraded"
+	// ConditionExpiring indicates at least one source certificate is within its
+	// ExpiryWarningDays window.
+	ConditionExpiring = "Expiring"
+	// ConditionVerified indicates whether every source certificate passed Spec.Verify.
+	ConditionVerified = "Verified"
+)
+
+// VerifyMode selects how source certificates are validated before being included in the
+// trust bundle.
+type VerifyMode string
+
+const (
+	// VerifyNone performs no verification; every extracted certificate is trusted.
+	VerifyNone VerifyMode = "none"
+	// VerifyChain verifies each certificate against the configured trust pool.
+	VerifyChain VerifyMode = "chain"
+	// VerifyStrict additionally requires KeyUsageCertSign on CA certificates and
+	// rejects certificates that are not currently valid.
+	VerifyStrict VerifyMode = "strict"
+)
+
+// ChainScope selects which certificates IncludeIntermediates emits into the trust
+// bundle.
+type ChainScope string
+
+const (
+	// ChainScopeFull emits the full leaf-to-root chain.
+	ChainScopeFull ChainScope = "full"
+	// ChainScopeAnchorsOnly emits only the intermediate and root CA certificates,
+	// excluding leaf certificates from the trust bundle.
+	ChainScopeAnchorsOnly ChainScope = "anchorsOnly"
+)
+
+// ConfigMapReference points at a ConfigMap used as a source of trust anchors.
+type ConfigMapReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// SourceSelector describes where to look for source certificates: which namespaces to
+// scan and which Secrets within them qualify.
+type SourceSelector struct {
+	// Namespaces lists the namespaces scanned for matching Secrets. Empty means all
+	// namespaces.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// SecretTypes lists the Secret types this source considers. Defaults to
+	// ["kubernetes.io/tls"] when empty.
+	// +optional
+	SecretTypes []corev1.SecretType `json:"secretTypes,omitempty"`
+
+	// LabelSelector restricts matching Secrets by label.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// AnnotationSelector restricts matching Secrets by annotation key/value pairs. A
+	// Secret matches when it carries every listed annotation with a matching value. An
+	// empty value matches any value for that key.
+	// +optional
+	AnnotationSelector map[string]string `json:"annotationSelector,omitempty"`
+}
+
+// SecretDestination configures an additional Secret written alongside the trust bundle
+// ConfigMap, carrying the same PEM data.
+type SecretDestination struct {
+	// Name is the Secret name. Defaults to "<watcher-name>-ca" when empty.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// BundleFormat selects a binary keystore encoding for a trust bundle.
+type BundleFormat string
+
+const (
+	// BundleFormatPKCS12 encodes the trust bundle as a PKCS#12 truststore.
+	BundleFormatPKCS12 BundleFormat = "pkcs12"
+	// BundleFormatJKS encodes the trust bundle as a Java KeyStore.
+	BundleFormatJKS BundleFormat = "jks"
+)
+
+// FormatOutput requests one binary encoding of the trust bundle.
+type FormatOutput struct {
+	// Format selects the binary encoding.
+	// +kubebuilder:validation:Enum=pkcs12;jks
+	Format BundleFormat `json:"format"`
+
+	// Key is the Secret data key the encoded bundle is written under. Defaults to
+	// "truststore.p12" for pkcs12 and "cacerts.jks" for jks.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// Destination describes where an aggregated trust bundle is written.
+type Destination struct {
+	// Namespaces lists the namespaces the trust bundle is written into.
+	Namespaces []string `json:"namespaces"`
+
+	// ConfigMapName is the name of the ConfigMap holding the PEM trust bundle. Defaults
+	// to "<watcher-name>-ca" when empty.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Secret additionally mirrors the trust bundle into a Secret.
+	// +optional
+	Secret *SecretDestination `json:"secret,omitempty"`
+
+	// Formats lists additional binary keystore encodings written into a companion
+	// Secret (BundleSecretName) alongside the PEM ConfigMap.
+	// +optional
+	Formats []FormatOutput `json:"formats,omitempty"`
+
+	// BundleSecretName names the companion Secret holding Formats output. Defaults to
+	// "<watcher-name>-ca-bundle" when empty.
+	// +optional
+	BundleSecretName string `json:"bundleSecretName,omitempty"`
+
+	// PasswordSecretRef references an existing Secret key to use as the keystore
+	// password for Formats. When unset, a random password is generated once and kept
+	// in the companion Secret's own "password" key.
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+}
+
+// TLSSecretWatcherSpec defines the desired state of TLSSecretWatcher
+type TLSSecretWatcherSpec struct {
+	// CheckCA restricts extraction to certificates whose CA basic constraint is set.
+	// +optional
+	CheckCA bool `json:"checkCA,omitempty"`
+
+	// DataKeys lists the Secret data keys inspected for PEM-encoded certificates.
+	// Defaults to ["tls.crt", "ca.crt"] when empty.
+	// +optional
+	DataKeys []string `json:"dataKeys,omitempty"`
+
+	// Sources lists the places to look for source certificates. Their results are
+	// aggregated into a single trust bundle.
+	Sources []SourceSelector `json:"sources"`
+
+	// Destinations lists where the aggregated trust bundle is written.
+	Destinations []Destination `json:"destinations"`
+
+	// ExpiryWarningDays is how many days before a certificate's NotAfter the
+	// ConditionExpiring condition and ExpiringSoon event fire. Defaults to 30.
+	// +optional
+	ExpiryWarningDays int32 `json:"expiryWarningDays,omitempty"`
+
+	// Verify selects how source certificates are validated before being included in
+	// the trust bundle. Defaults to "none".
+	// +kubebuilder:validation:Enum=none;chain;strict
+	// +optional
+	Verify VerifyMode `json:"verify,omitempty"`
+
+	// TrustAnchorConfigMaps lists ConfigMaps whose PEM data form the trust pool used by
+	// Verify "chain"/"strict". When empty, the host's system certificate pool is used.
+	// +optional
+	TrustAnchorConfigMaps []ConfigMapReference `json:"trustAnchorConfigMaps,omitempty"`
+
+	// IncludeIntermediates orders the trust bundle as leaf -> intermediate -> root
+	// instead of the order certificates were encountered in: non-CA certificates first,
+	// then CA certificates, then roots (CA certificates whose AuthorityKeyId is absent or
+	// equal to their SubjectKeyId). Certificates within each group keep their encounter
+	// order; issuers are not matched to subjects, so several intermediates are not put
+	// into chain order among themselves.
+	// +optional
+	IncludeIntermediates bool `json:"includeIntermediates,omitempty"`
+
+	// ChainScope selects which of the ordered certificates are emitted into the trust
+	// bundle: the full chain (default), or only the intermediate/root trust anchors.
+	// Ignored unless IncludeIntermediates is set.
+	// +kubebuilder:validation:Enum=full;anchorsOnly
+	// +optional
+	ChainScope ChainScope `json:"chainScope,omitempty"`
+}
+
+// CertificateStatus reports the observed state of a single source certificate.
+type CertificateStatus struct {
+	// SecretName is the name of the Secret the certificate was extracted from.
+	SecretName string `json:"secretName"`
+
+	// SecretNamespace is the namespace of the Secret the certificate was extracted from.
+	SecretNamespace string `json:"secretNamespace"`
+
+	SerialNumber string `json:"serialNumber"`
+	Issuer       string `json:"issuer"`
+	Subject      string `json:"subject"`
+
+	NotBefore metav1.Time `json:"notBefore"`
+	NotAfter  metav1.Time `json:"notAfter"`
+
+	IsCA bool `json:"isCA"`
+
+	// DaysUntilExpiry is computed at reconcile time from NotAfter; it may be negative
+	// for an already-expired certificate.
+	DaysUntilExpiry int64 `json:"daysUntilExpiry"`
+}
+
+// TLSSecretWatcherStatus defines the observed state of TLSSecretWatcher
+type TLSSecretWatcherStatus struct {
+	// Sources reports every source certificate that fed the last reconciled trust
+	// bundle.
+	// +optional
+	Sources []CertificateStatus `json:"sources,omitempty"`
+
+	// Conditions holds the latest observations, keyed by type (Ready, Degraded,
+	// Expiring, Verified).
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+
+// TLSSecretWatcher is the Schema for the tlssecretwatchers API. It is cluster-scoped so
+// a single instance can aggregate source Secrets from, and write trust bundles into,
+// any namespace.
+type TLSSecretWatcher struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TLSSecretWatcherSpec   `json:"spec,omitempty"`
+	Status TLSSecretWatcherStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TLSSecretWatcherList contains a list of TLSSecretWatcher
+type TLSSecretWatcherList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TLSSecretWatcher `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TLSSecretWatcher{}, &TLSSecretWatcherList{})
+}
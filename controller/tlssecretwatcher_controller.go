@@ -1,121 +1,719 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/pem"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	v1 "pottmeier.de/api/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"pottmeier.de/internal/bundle"
+	"pottmeier.de/internal/metrics"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1 "pottmeier.de/api/v1"
 )
 
+// bundlePasswordKey is the Secret data key holding a generated keystore password.
+const bundlePasswordKey = "password"
+
+// certSetHashAnnotation records the SHA-256 hash of the sorted DER certificates that
+// produced a bundle Secret, so unchanged cert sets are not re-encoded and re-applied.
+const certSetHashAnnotation = "de.pottmeier.converter/cert-set-hash"
+
+// createCAAnnotation is the legacy opt-in annotation used by a SourceSelector that
+// defines neither a LabelSelector nor an AnnotationSelector.
+const createCAAnnotation = "de.pottmeier.converter/createca"
+
 type TLSSecretWatcherReconciler struct {
 	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	certMetricsMu    sync.Mutex
+	certMetricLabels map[string][][]string
+}
+
+// certInfo pairs a parsed, deduplicated source certificate with the Secret it was
+// extracted from, so the reconciler can report metrics, events, and status per source.
+type certInfo struct {
+	pem             string
+	secretName      string
+	secretNamespace string
+	cert            *x509.Certificate
 }
 
 // +kubebuilder:rbac:groups=cert.pottmeier.de/v1,resources=tlssecretwatchers,verbs=get;list;watch
-// +kubebuilder:rbac:groups=v1,resources=secrets,verbs=get;watch;list
+// +kubebuilder:rbac:groups=cert.pottmeier.de/v1,resources=tlssecretwatchers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=v1,resources=secrets,verbs=get;watch;list;create;update;patch
 // +kubebuilder:rbac:groups=v1,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=v1,resources=events,verbs=create;patch
 
+// Reconcile aggregates the CA certificates found by every Source of the named
+// TLSSecretWatcher into a single trust bundle, writes it into each Destination, and
+// reports expiry metrics, events, and status for every source certificate.
 func (r *TLSSecretWatcherReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	// Read Custom Resource
+	logger := logf.FromContext(ctx)
+
 	var watcher v1.TLSSecretWatcher
-	var target = client.ObjectKey{Namespace: req.Namespace, Name: "default"}
+	if err := r.Get(ctx, req.NamespacedName, &watcher); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("Reconciliation triggered", "watcher", watcher.Name)
 
-	if err := r.Get(ctx, target, &watcher); err != nil {
+	certs, err := r.collectCerts(ctx, watcher)
+	if err != nil {
+		metrics.ReconcileTotal.WithLabelValues(watcher.Name, "error").Inc()
+		logger.Error(err, "failed to list source Secrets")
 		return ctrl.Result{}, err
 	}
 
-	logger := logf.FromContext(ctx)
+	certs, verifyErr := r.verifyCerts(ctx, &watcher, certs)
+	if verifyErr != nil {
+		metrics.ReconcileTotal.WithLabelValues(watcher.Name, "error").Inc()
+		logger.Error(verifyErr, "failed to build trust anchor pool")
+		return ctrl.Result{}, verifyErr
+	}
 
-	logger.Info("Reconciliation triggered")
+	// bundleCerts is what gets written into every Destination; certs (unfiltered) is
+	// what Status.Sources and the expiry metrics report, so a narrowed ChainScope never
+	// hides a source certificate's expiry from observability.
+	bundleCerts := certs
+	if watcher.Spec.IncludeIntermediates {
+		bundleCerts = sortChain(certs)
+		if watcher.Spec.ChainScope == v1.ChainScopeAnchorsOnly {
+			bundleCerts = anchorsOnly(bundleCerts)
+		}
+	}
 
-	var secret corev1.Secret
-	if err := r.Get(ctx, req.NamespacedName, &secret); err != nil {
-		if errors.IsNotFound(err) {
-			return ctrl.Result{}, nil
+	earliestExpiry := r.reportCertStatus(&watcher, certs)
+
+	if len(certs) == 0 {
+		logger.Info("Keine CA-Zertifikate gefunden")
+		meta.SetStatusCondition(&watcher.Status.Conditions, metav1.Condition{
+			Type: v1.ConditionReady, Status: metav1.ConditionFalse, Reason: "NoCertificates",
+			Message: "no source certificates matched any Source selector",
+		})
+		if err := r.Status().Update(ctx, &watcher); err != nil {
+			logger.Error(err, "failed to update status")
+			return ctrl.Result{}, err
+		}
+		metrics.ReconcileTotal.WithLabelValues(watcher.Name, "no_certificates").Inc()
+		return ctrl.Result{}, nil
+	}
+
+	for _, dest := range watcher.Spec.Destinations {
+		for _, ns := range dest.Namespaces {
+			if err := r.writeDestination(ctx, watcher, dest, ns, bundleCerts); err != nil {
+				logger.Error(err, "trust bundle reconcile failed", "namespace", ns)
+				meta.SetStatusCondition(&watcher.Status.Conditions, metav1.Condition{
+					Type: v1.ConditionDegraded, Status: metav1.ConditionTrue, Reason: "DestinationWriteFailed", Message: err.Error(),
+				})
+				_ = r.Status().Update(ctx, &watcher)
+				metrics.ReconcileTotal.WithLabelValues(watcher.Name, "error").Inc()
+				return ctrl.Result{}, err
+			}
 		}
+	}
+
+	meta.SetStatusCondition(&watcher.Status.Conditions, metav1.Condition{
+		Type: v1.ConditionReady, Status: metav1.ConditionTrue, Reason: "Reconciled", Message: "trust bundle reconciled",
+	})
+	meta.SetStatusCondition(&watcher.Status.Conditions, metav1.Condition{
+		Type: v1.ConditionDegraded, Status: metav1.ConditionFalse, Reason: "Reconciled", Message: "trust bundle reconciled",
+	})
+	if err := r.Status().Update(ctx, &watcher); err != nil {
+		logger.Error(err, "failed to update status")
 		return ctrl.Result{}, err
 	}
+	metrics.ReconcileTotal.WithLabelValues(watcher.Name, "success").Inc()
 
-	// Nur TLS Secrets mit Annotation "de.pottmeier.converter/createca"
-	if secret.Type != corev1.SecretTypeTLS {
-		return ctrl.Result{}, nil
+	result := ctrl.Result{}
+	if earliestExpiry != nil {
+		if d := time.Until(*earliestExpiry); d > 0 {
+			result.RequeueAfter = d
+		}
 	}
+	return result, nil
+}
 
-	if _, ok := secret.Annotations["de.pottmeier.converter/createca"]; !ok {
-		return ctrl.Result{}, nil
+// reportCertStatus populates watcher.Status.Sources, emits the certificate expiry
+// metrics, and fires an ExpiringSoon event for every cert within ExpiryWarningDays of
+// its NotAfter. It returns the earliest NotAfter across all certs, or nil if there are
+// none.
+func (r *TLSSecretWatcherReconciler) reportCertStatus(watcher *v1.TLSSecretWatcher, certs []certInfo) *time.Time {
+	warningDays := watcher.Spec.ExpiryWarningDays
+	if warningDays <= 0 {
+		warningDays = v1.DefaultExpiryWarningDays
 	}
-	logger.Info("Found secret " + secret.Name)
+	warningWindow := time.Duration(warningDays) * 24 * time.Hour
 
-	// CA extrahieren aus tls.crt
-	crtData := secret.Data["tls.crt"]
-	if crtData == nil {
-		logger.Info("tls.crt missing in Secret")
-		return ctrl.Result{}, nil
+	var expiringSoon bool
+	var earliest *time.Time
+	watcher.Status.Sources = make([]v1.CertificateStatus, 0, len(certs))
+	labelSets := make([][]string, 0, len(certs))
+
+	for _, ci := range certs {
+		watcher.Status.Sources = append(watcher.Status.Sources, v1.CertificateStatus{
+			SecretName:      ci.secretName,
+			SecretNamespace: ci.secretNamespace,
+			SerialNumber:    ci.cert.SerialNumber.String(),
+			Issuer:          ci.cert.Issuer.String(),
+			Subject:         ci.cert.Subject.String(),
+			NotBefore:       metav1.NewTime(ci.cert.NotBefore),
+			NotAfter:        metav1.NewTime(ci.cert.NotAfter),
+			IsCA:            ci.cert.IsCA,
+			DaysUntilExpiry: int64(time.Until(ci.cert.NotAfter) / (24 * time.Hour)),
+		})
+
+		labelValues := []string{ci.secretName, ci.secretNamespace, ci.cert.Subject.String(), ci.cert.SerialNumber.String(), ci.cert.Issuer.String()}
+		labelSets = append(labelSets, labelValues)
+		metrics.CertificateNotAfterSeconds.WithLabelValues(labelValues...).Set(float64(ci.cert.NotAfter.Unix()))
+
+		if time.Until(ci.cert.NotAfter) <= warningWindow {
+			metrics.CertificateExpiringSoon.WithLabelValues(labelValues...).Set(1)
+			expiringSoon = true
+			if r.Recorder != nil {
+				r.Recorder.Eventf(watcher, corev1.EventTypeWarning, "ExpiringSoon",
+					"certificate %q from Secret %s/%s expires at %s", ci.cert.Subject, ci.secretNamespace, ci.secretName, ci.cert.NotAfter)
+			}
+		} else {
+			metrics.CertificateExpiringSoon.WithLabelValues(labelValues...).Set(0)
+		}
+
+		notAfter := ci.cert.NotAfter
+		if earliest == nil || notAfter.Before(*earliest) {
+			earliest = &notAfter
+		}
 	}
 
-	caCerts := extractCerts(crtData, watcher)
+	expiringStatus := metav1.ConditionFalse
+	if expiringSoon {
+		expiringStatus = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&watcher.Status.Conditions, metav1.Condition{
+		Type: v1.ConditionExpiring, Status: expiringStatus, Reason: "ExpiryChecked",
+		Message: "certificate expiry evaluated against ExpiryWarningDays",
+	})
 
-	if len(caCerts) == 0 {
-		logger.Info("Keine CA-Zertifikate gefunden")
-		return ctrl.Result{}, nil
+	r.pruneCertMetrics(watcher.Name, labelSets)
+
+	return earliest
+}
+
+// pruneCertMetrics deletes the CertificateNotAfterSeconds/CertificateExpiringSoon
+// series left over from watcher's previous reconcile that are absent from current,
+// e.g. because a certificate rotated to a new serial or its Secret no longer matches.
+// Gauge.Set alone never retires a series, so without this a long-running operator
+// leaks label series and leaves stale "expiring soon" gauges stuck at their last value
+// forever.
+func (r *TLSSecretWatcherReconciler) pruneCertMetrics(watcherName string, current [][]string) {
+	r.certMetricsMu.Lock()
+	defer r.certMetricsMu.Unlock()
+
+	if r.certMetricLabels == nil {
+		r.certMetricLabels = map[string][][]string{}
+	}
+
+	currentSet := make(map[string]bool, len(current))
+	for _, labelValues := range current {
+		currentSet[stringJoin(labelValues, "\x00")] = true
 	}
 
-	// ConfigMap erzeugen
-	cm := cmBuilder(req, caCerts)
+	for _, labelValues := range r.certMetricLabels[watcherName] {
+		if currentSet[stringJoin(labelValues, "\x00")] {
+			continue
+		}
+		metrics.CertificateNotAfterSeconds.DeleteLabelValues(labelValues...)
+		metrics.CertificateExpiringSoon.DeleteLabelValues(labelValues...)
+	}
 
-	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error { return nil })
+	r.certMetricLabels[watcherName] = current
+}
 
+// verifyCerts applies watcher.Spec.Verify to certs, dropping and counting any that
+// fail, and records the result as the Verified condition. VerifyNone (the default)
+// returns certs unchanged.
+func (r *TLSSecretWatcherReconciler) verifyCerts(ctx context.Context, watcher *v1.TLSSecretWatcher, certs []certInfo) ([]certInfo, error) {
+	if watcher.Spec.Verify == "" || watcher.Spec.Verify == v1.VerifyNone {
+		return certs, nil
+	}
+
+	roots, err := r.trustPool(ctx, watcher.Spec.TrustAnchorConfigMaps)
 	if err != nil {
-		logger.Error(err, "ConfigMap reconcile failed")
-		return ctrl.Result{}, err
-	} else {
-		logger.Info("ConfigMap successfully reconciled", "operation", op)
-		logger.Info("ConfigMap mit CA-Zertifikaten erzeugt", "name", cm.Name)
-		return ctrl.Result{}, nil
+		return nil, err
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, ci := range certs {
+		if ci.cert.IsCA {
+			intermediates.AddCert(ci.cert)
+		}
 	}
 
+	logger := logf.FromContext(ctx)
+	verified := make([]certInfo, 0, len(certs))
+	failed := false
+
+	for _, ci := range certs {
+		if err := verifyCert(ci.cert, watcher.Spec.Verify, roots, intermediates); err != nil {
+			failed = true
+			metrics.VerificationFailuresTotal.WithLabelValues(watcher.Name, ci.secretName, ci.secretNamespace).Inc()
+			logger.Info("skipping certificate that failed verification",
+				"secret", ci.secretNamespace+"/"+ci.secretName, "subject", ci.cert.Subject.String(), "error", err.Error())
+			continue
+		}
+		verified = append(verified, ci)
+	}
+
+	verifiedStatus := metav1.ConditionTrue
+	reason := "VerificationPassed"
+	if failed {
+		verifiedStatus = metav1.ConditionFalse
+		reason = "VerificationFailed"
+	}
+	meta.SetStatusCondition(&watcher.Status.Conditions, metav1.Condition{
+		Type: v1.ConditionVerified, Status: verifiedStatus, Reason: reason,
+		Message: fmt.Sprintf("verify mode %q applied to %d source certificate(s)", watcher.Spec.Verify, len(certs)),
+	})
+
+	return verified, nil
 }
 
-func extractCerts(crtData []byte, watcher v1.TLSSecretWatcher) []string {
-	var caCerts []string
-	rest := crtData
-	for {
-		var block *pem.Block
-		block, rest = pem.Decode(rest)
-		if block == nil {
-			break
+// trustPool builds the CertPool used by Spec.Verify from the given ConfigMap
+// references, falling back to the host's system certificate pool when none are given.
+func (r *TLSSecretWatcherReconciler) trustPool(ctx context.Context, refs []v1.ConfigMapReference) (*x509.CertPool, error) {
+	if len(refs) == 0 {
+		if pool, err := x509.SystemCertPool(); err == nil && pool != nil {
+			return pool, nil
 		}
-		cert, err := x509.ParseCertificate(block.Bytes)
-		if err != nil {
-			continue
+		return x509.NewCertPool(), nil
+	}
+
+	pool := x509.NewCertPool()
+	for _, ref := range refs {
+		var cm corev1.ConfigMap
+		if err := r.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, &cm); err != nil {
+			return nil, err
+		}
+		for _, data := range cm.Data {
+			pool.AppendCertsFromPEM([]byte(data))
+		}
+	}
+	return pool, nil
+}
+
+// verifyCert checks cert against roots/intermediates, additionally enforcing
+// KeyUsageCertSign on CA certificates and current validity under VerifyStrict.
+func verifyCert(cert *x509.Certificate, mode v1.VerifyMode, roots, intermediates *x509.CertPool) error {
+	if mode == v1.VerifyStrict {
+		if cert.IsCA && cert.KeyUsage&x509.KeyUsageCertSign == 0 {
+			return fmt.Errorf("certificate %s is a CA without KeyUsageCertSign", cert.Subject)
+		}
+		now := time.Now()
+		if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+			return fmt.Errorf("certificate %s is not currently valid", cert.Subject)
 		}
+	}
+
+	_, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err
+}
+
+// sortChain orders certs leaf -> intermediate -> root, identifying roots by a
+// self-referencing AuthorityKeyId/SubjectKeyId (or its absence) and intermediates as
+// any other CA certificate.
+func sortChain(certs []certInfo) []certInfo {
+	sorted := make([]certInfo, len(certs))
+	copy(sorted, certs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return chainRank(sorted[i].cert) < chainRank(sorted[j].cert)
+	})
+	return sorted
+}
+
+func chainRank(cert *x509.Certificate) int {
+	if !cert.IsCA {
+		return 0
+	}
+	if len(cert.AuthorityKeyId) == 0 || bytes.Equal(cert.AuthorityKeyId, cert.SubjectKeyId) {
+		return 2
+	}
+	return 1
+}
 
-		if cert.IsCA || !watcher.Spec.CheckCA {
-			caCerts = append(caCerts, string(pem.EncodeToMemory(block)))
+// anchorsOnly filters an already-sorted certs slice down to intermediate and root CA
+// certificates, dropping leaf certificates so ChainScopeAnchorsOnly bundles contain only
+// trust anchors.
+func anchorsOnly(certs []certInfo) []certInfo {
+	anchors := make([]certInfo, 0, len(certs))
+	for _, ci := range certs {
+		if chainRank(ci.cert) > 0 {
+			anchors = append(anchors, ci)
 		}
 	}
-	return caCerts
+	return anchors
 }
 
-func cmBuilder(req ctrl.Request, caCerts []string) *corev1.ConfigMap {
+// writeDestination creates or updates the trust bundle ConfigMap, optional Secret
+// mirror, and optional binary keystore bundle, for a single Destination/namespace pair.
+func (r *TLSSecretWatcherReconciler) writeDestination(ctx context.Context, watcher v1.TLSSecretWatcher, dest v1.Destination, namespace string, certs []certInfo) error {
+	logger := logf.FromContext(ctx)
+	pemBundle := bundlePEM(certs)
+
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      req.Name + "-ca",
-			Namespace: req.Namespace,
-		},
-		Data: map[string]string{
-			"ca.crt": stringJoin(caCerts, "\n"),
+			Name:      destinationConfigMapName(watcher, dest),
+			Namespace: namespace,
 		},
 	}
-	return cm
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Data = map[string]string{"ca.crt": pemBundle}
+		return controllerutil.SetControllerReference(&watcher, cm, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+	logger.Info("ConfigMap mit CA-Zertifikaten erzeugt", "name", cm.Name, "namespace", namespace, "operation", op)
+
+	if dest.Secret != nil {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      destinationSecretName(watcher, dest),
+				Namespace: namespace,
+			},
+		}
+		op, err = controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+			secret.Data = map[string][]byte{"ca.crt": []byte(pemBundle)}
+			return controllerutil.SetControllerReference(&watcher, secret, r.Scheme)
+		})
+		if err != nil {
+			return err
+		}
+		logger.Info("Secret mit CA-Zertifikaten erzeugt", "name", secret.Name, "namespace", namespace, "operation", op)
+	}
+
+	return r.writeBundleSecret(ctx, watcher, dest, namespace, certs)
+}
+
+func destinationConfigMapName(watcher v1.TLSSecretWatcher, dest v1.Destination) string {
+	if dest.ConfigMapName != "" {
+		return dest.ConfigMapName
+	}
+	return watcher.Name + "-ca"
+}
+
+func destinationSecretName(watcher v1.TLSSecretWatcher, dest v1.Destination) string {
+	if dest.Secret.Name != "" {
+		return dest.Secret.Name
+	}
+	return watcher.Name + "-ca"
+}
+
+func bundleSecretName(watcher v1.TLSSecretWatcher, dest v1.Destination) string {
+	if dest.BundleSecretName != "" {
+		return dest.BundleSecretName
+	}
+	return watcher.Name + "-ca-bundle"
+}
+
+// writeBundleSecret encodes certs into every format requested by dest.Formats and
+// writes them, alongside the keystore password, into the companion bundle Secret. It
+// is a no-op when dest.Formats is empty, and skips re-encoding when the cert set's hash
+// matches what was written last time, so downstream pods mounting the Secret are not
+// rolled needlessly.
+func (r *TLSSecretWatcherReconciler) writeBundleSecret(ctx context.Context, watcher v1.TLSSecretWatcher, dest v1.Destination, namespace string, certs []certInfo) error {
+	if len(dest.Formats) == 0 {
+		return nil
+	}
+
+	rawCerts := make([]*x509.Certificate, len(certs))
+	for i, ci := range certs {
+		rawCerts[i] = ci.cert
+	}
+	hash := certSetHash(rawCerts)
+
+	existing := &corev1.Secret{}
+	key := client.ObjectKey{Name: bundleSecretName(watcher, dest), Namespace: namespace}
+	if err := r.Get(ctx, key, existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		existing = nil
+	} else if existing.Annotations[certSetHashAnnotation] == hash {
+		return nil
+	}
+
+	password, err := r.bundlePassword(ctx, dest, namespace, existing)
+	if err != nil {
+		return err
+	}
+
+	data := map[string][]byte{bundlePasswordKey: []byte(password)}
+	for _, format := range dest.Formats {
+		encoder := bundle.EncoderFor(format.Format)
+		if encoder == nil {
+			return fmt.Errorf("unsupported bundle format %q", format.Format)
+		}
+		dataKey := format.Key
+		if dataKey == "" {
+			dataKey = encoder.DefaultKey()
+		}
+		encoded, err := encoder.Encode(rawCerts, password)
+		if err != nil {
+			return fmt.Errorf("encoding %s bundle: %w", format.Format, err)
+		}
+		data[dataKey] = encoded
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		secret.Data = data
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[certSetHashAnnotation] = hash
+		return controllerutil.SetControllerReference(&watcher, secret, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+	logf.FromContext(ctx).Info("bundle Secret reconciled", "name", secret.Name, "namespace", namespace, "operation", op)
+	return nil
+}
+
+// bundlePassword resolves the keystore password for a Destination: an explicit
+// PasswordSecretRef wins, then a password already stored in the companion Secret (so it
+// stays stable across reconciles), otherwise a new random password is generated.
+func (r *TLSSecretWatcherReconciler) bundlePassword(ctx context.Context, dest v1.Destination, namespace string, existing *corev1.Secret) (string, error) {
+	if dest.PasswordSecretRef != nil {
+		var ref corev1.Secret
+		if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: dest.PasswordSecretRef.Name}, &ref); err != nil {
+			return "", err
+		}
+		password, ok := ref.Data[dest.PasswordSecretRef.Key]
+		if !ok {
+			return "", fmt.Errorf("key %q not found in Secret %s/%s", dest.PasswordSecretRef.Key, namespace, dest.PasswordSecretRef.Name)
+		}
+		return string(password), nil
+	}
+
+	if existing != nil {
+		if password, ok := existing.Data[bundlePasswordKey]; ok {
+			return string(password), nil
+		}
+	}
+
+	return generatePassword()
+}
+
+func generatePassword() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// certSetHash fingerprints a set of certificates independent of encounter order, so
+// writeBundleSecret can detect an unchanged cert set regardless of reconcile ordering.
+func certSetHash(certs []*x509.Certificate) string {
+	sorted := make([]*x509.Certificate, len(certs))
+	copy(sorted, certs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Raw, sorted[j].Raw) < 0
+	})
+
+	h := sha256.New()
+	for _, cert := range sorted {
+		h.Write(cert.Raw)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// collectCerts lists the Secrets matched by every Source of watcher and returns their
+// extracted certificates, deduplicated by SHA-256 fingerprint across all sources.
+func (r *TLSSecretWatcherReconciler) collectCerts(ctx context.Context, watcher v1.TLSSecretWatcher) ([]certInfo, error) {
+	seen := map[[sha256.Size]byte]bool{}
+	var certs []certInfo
+
+	for _, source := range watcher.Spec.Sources {
+		namespaces := source.Namespaces
+		if len(namespaces) == 0 {
+			namespaces = []string{metav1.NamespaceAll}
+		}
+
+		// Narrow the List itself by source.LabelSelector when one is set, instead of
+		// fetching every Secret in the namespace and discarding non-matches in
+		// sourceMatchesSecret. AnnotationSelector and the legacy createCAAnnotation
+		// opt-in have no server-side equivalent in the Secret API, so those still fall
+		// through to sourceMatchesSecret.
+		listOpts := []client.ListOption{}
+		if source.LabelSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(source.LabelSelector)
+			if err != nil {
+				return nil, err
+			}
+			listOpts = append(listOpts, client.MatchingLabelsSelector{Selector: selector})
+		}
+
+		for _, ns := range namespaces {
+			var secrets corev1.SecretList
+			if err := r.List(ctx, &secrets, append([]client.ListOption{client.InNamespace(ns)}, listOpts...)...); err != nil {
+				return nil, err
+			}
+			for _, secret := range secrets.Items {
+				if !sourceMatchesSecret(source, secret) {
+					continue
+				}
+				certs = append(certs, extractCerts(secret, watcher, seen)...)
+			}
+		}
+	}
+	return certs, nil
+}
+
+// sourceMatchesSecret reports whether secret is a certificate source selected by source:
+// its namespace and type must be allowed, and it must satisfy the LabelSelector/
+// AnnotationSelector if any are set. When neither selector is configured it falls back
+// to the legacy createCAAnnotation opt-in.
+func sourceMatchesSecret(source v1.SourceSelector, secret corev1.Secret) bool {
+	if len(source.Namespaces) > 0 && !containsString(source.Namespaces, secret.Namespace) {
+		return false
+	}
+
+	if !secretTypeAllowed(secret.Type, source.SecretTypes) {
+		return false
+	}
+
+	if source.LabelSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(source.LabelSelector)
+		if err != nil || !selector.Matches(labels.Set(secret.Labels)) {
+			return false
+		}
+	}
+
+	if len(source.AnnotationSelector) > 0 {
+		for key, value := range source.AnnotationSelector {
+			got, ok := secret.Annotations[key]
+			if !ok || (value != "" && got != value) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if source.LabelSelector != nil {
+		return true
+	}
+
+	_, ok := secret.Annotations[createCAAnnotation]
+	return ok
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func secretTypeAllowed(secretType corev1.SecretType, allowed []corev1.SecretType) bool {
+	if len(allowed) == 0 {
+		allowed = v1.DefaultSecretTypes
+	}
+	for _, t := range allowed {
+		if t == secretType {
+			return true
+		}
+	}
+	return false
+}
+
+// extractCerts reads every key configured via watcher.Spec.DataKeys (default
+// ["tls.crt", "ca.crt"]) out of secret.Data, parses the PEM blocks it finds and returns
+// those matching watcher's CA requirement. seen tracks certificate SHA-256 fingerprints
+// already emitted, so callers can dedupe across multiple secrets and sources.
+func extractCerts(secret corev1.Secret, watcher v1.TLSSecretWatcher, seen map[[sha256.Size]byte]bool) []certInfo {
+	keys := watcher.Spec.DataKeys
+	if len(keys) == 0 {
+		keys = v1.DefaultDataKeys
+	}
+
+	var certs []certInfo
+
+	for _, key := range keys {
+		rest := secret.Data[key]
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				continue
+			}
+
+			if watcher.Spec.CheckCA && !cert.IsCA {
+				continue
+			}
+
+			fingerprint := sha256.Sum256(cert.Raw)
+			if seen[fingerprint] {
+				continue
+			}
+			seen[fingerprint] = true
+
+			certs = append(certs, certInfo{
+				pem:             string(pem.EncodeToMemory(block)),
+				secretName:      secret.Name,
+				secretNamespace: secret.Namespace,
+				cert:            cert,
+			})
+		}
+	}
+	return certs
+}
+
+// bundlePEM concatenates every certificate's PEM block into a single trust bundle.
+func bundlePEM(certs []certInfo) string {
+	pems := make([]string, len(certs))
+	for i, ci := range certs {
+		pems[i] = ci.pem
+	}
+	return stringJoin(pems, "\n")
 }
 
 func stringJoin(strs []string, sep string) string {
@@ -129,9 +727,49 @@ func stringJoin(strs []string, sep string) string {
 	return result
 }
 
+// watchedSecretType is a coarse pre-filter applied at watch registration time; fine
+// grained matching against each TLSSecretWatcher's Sources happens in mapSecretToWatcher.
+var watchedSecretType = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return false
+	}
+	return secret.Type == corev1.SecretTypeTLS || secret.Type == corev1.SecretTypeOpaque
+})
+
+// mapSecretToWatcher enqueues a reconcile Request for every TLSSecretWatcher whose
+// Sources match the Secret that triggered the event.
+func (r *TLSSecretWatcherReconciler) mapSecretToWatcher(ctx context.Context, obj client.Object) []reconcile.Request {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return nil
+	}
+
+	var watchers v1.TLSSecretWatcherList
+	if err := r.List(ctx, &watchers); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list TLSSecretWatchers")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, watcher := range watchers.Items {
+		for _, source := range watcher.Spec.Sources {
+			if sourceMatchesSecret(source, *secret) {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&watcher)})
+				break
+			}
+		}
+	}
+	return requests
+}
+
 func (r *TLSSecretWatcherReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// Setup the controller to watch for Secret resources
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&corev1.Secret{}).
+		For(&v1.TLSSecretWatcher{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.mapSecretToWatcher),
+			builder.WithPredicates(watchedSecretType),
+		).
 		Complete(r)
 }
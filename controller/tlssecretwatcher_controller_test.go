@@ -0,0 +1,344 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "pottmeier.de/api/v1"
+	"pottmeier.de/internal/metrics"
+)
+
+func TestSourceMatchesSecret(t *testing.T) {
+	tests := []struct {
+		name   string
+		source v1.SourceSelector
+		secret corev1.Secret
+		want   bool
+	}{
+		{
+			name:   "no selectors falls back to legacy annotation opt-in, present",
+			source: v1.SourceSelector{},
+			secret: corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{createCAAnnotation: ""}},
+				Type:       corev1.SecretTypeTLS,
+			},
+			want: true,
+		},
+		{
+			name:   "no selectors falls back to legacy annotation opt-in, absent",
+			source: v1.SourceSelector{},
+			secret: corev1.Secret{Type: corev1.SecretTypeTLS},
+			want:   false,
+		},
+		{
+			name:   "namespace restriction excludes non-listed namespace",
+			source: v1.SourceSelector{Namespaces: []string{"prod"}},
+			secret: corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "staging", Annotations: map[string]string{createCAAnnotation: ""}},
+				Type:       corev1.SecretTypeTLS,
+			},
+			want: false,
+		},
+		{
+			name:   "default secret type excludes Opaque",
+			source: v1.SourceSelector{},
+			secret: corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{createCAAnnotation: ""}},
+				Type:       corev1.SecretTypeOpaque,
+			},
+			want: false,
+		},
+		{
+			name:   "explicit secret type allows Opaque",
+			source: v1.SourceSelector{SecretTypes: []corev1.SecretType{corev1.SecretTypeOpaque}},
+			secret: corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{createCAAnnotation: ""}},
+				Type:       corev1.SecretTypeOpaque,
+			},
+			want: true,
+		},
+		{
+			name: "label selector matches without requiring legacy annotation",
+			source: v1.SourceSelector{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"trust": "yes"}},
+			},
+			secret: corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"trust": "yes"}},
+				Type:       corev1.SecretTypeTLS,
+			},
+			want: true,
+		},
+		{
+			name: "label selector rejects non-matching labels",
+			source: v1.SourceSelector{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"trust": "yes"}},
+			},
+			secret: corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"trust": "no"}},
+				Type:       corev1.SecretTypeTLS,
+			},
+			want: false,
+		},
+		{
+			name: "annotation selector with empty value wildcards the key",
+			source: v1.SourceSelector{
+				AnnotationSelector: map[string]string{"some.key/present": ""},
+			},
+			secret: corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"some.key/present": "anything"}},
+				Type:       corev1.SecretTypeTLS,
+			},
+			want: true,
+		},
+		{
+			name: "annotation selector requires an exact value match when set",
+			source: v1.SourceSelector{
+				AnnotationSelector: map[string]string{"some.key/present": "expected"},
+			},
+			secret: corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"some.key/present": "other"}},
+				Type:       corev1.SecretTypeTLS,
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sourceMatchesSecret(tt.source, tt.secret); got != tt.want {
+				t.Errorf("sourceMatchesSecret() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractCerts(t *testing.T) {
+	root, rootKey := testCA(t, "root")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: root.Raw}
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ca-secret", Namespace: "default"},
+		Data:       map[string][]byte{"tls.crt": pem.EncodeToMemory(block)},
+	}
+
+	t.Run("extracts and tags certificates with their source Secret", func(t *testing.T) {
+		watcher := v1.TLSSecretWatcher{}
+		seen := map[[sha256.Size]byte]bool{}
+		certs := extractCerts(secret, watcher, seen)
+		if len(certs) != 1 {
+			t.Fatalf("len(certs) = %d, want 1", len(certs))
+		}
+		if certs[0].secretName != "ca-secret" || certs[0].secretNamespace != "default" {
+			t.Errorf("certs[0] secret ref = %s/%s, want default/ca-secret", certs[0].secretNamespace, certs[0].secretName)
+		}
+	})
+
+	t.Run("dedupes across calls sharing the same seen map", func(t *testing.T) {
+		watcher := v1.TLSSecretWatcher{}
+		seen := map[[sha256.Size]byte]bool{}
+		first := extractCerts(secret, watcher, seen)
+		second := extractCerts(secret, watcher, seen)
+		if len(first) != 1 || len(second) != 0 {
+			t.Fatalf("len(first)=%d len(second)=%d, want 1, 0", len(first), len(second))
+		}
+	})
+
+	t.Run("CheckCA drops non-CA certificates", func(t *testing.T) {
+		leaf := testLeafSignedBy(t, "leaf", root, rootKey, time.Now().Add(time.Hour))
+		leafSecret := corev1.Secret{
+			Data: map[string][]byte{"tls.crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw})},
+		}
+		watcher := v1.TLSSecretWatcher{Spec: v1.TLSSecretWatcherSpec{CheckCA: true}}
+		seen := map[[sha256.Size]byte]bool{}
+		if certs := extractCerts(leafSecret, watcher, seen); len(certs) != 0 {
+			t.Errorf("len(certs) = %d, want 0 when CheckCA excludes a non-CA leaf", len(certs))
+		}
+	})
+
+	t.Run("missing DataKeys falls back to defaults and ignores unparsable data", func(t *testing.T) {
+		secretWithJunk := corev1.Secret{
+			Data: map[string][]byte{"ca.crt": []byte("not pem"), "tls.crt": pem.EncodeToMemory(block)},
+		}
+		watcher := v1.TLSSecretWatcher{}
+		seen := map[[sha256.Size]byte]bool{}
+		if certs := extractCerts(secretWithJunk, watcher, seen); len(certs) != 1 {
+			t.Errorf("len(certs) = %d, want 1", len(certs))
+		}
+	})
+}
+
+func TestSortChainAndChainRank(t *testing.T) {
+	root, rootKey := testCA(t, "root")
+	leaf := testLeafSignedBy(t, "leaf", root, rootKey, time.Now().Add(time.Hour))
+
+	if rank := chainRank(root); rank != 2 {
+		t.Errorf("chainRank(root) = %d, want 2", rank)
+	}
+	if rank := chainRank(leaf); rank != 0 {
+		t.Errorf("chainRank(leaf) = %d, want 0", rank)
+	}
+
+	unsorted := []certInfo{{cert: root}, {cert: leaf}}
+	sorted := sortChain(unsorted)
+	if sorted[0].cert != leaf || sorted[1].cert != root {
+		t.Errorf("sortChain did not order leaf before root")
+	}
+	// sortChain must not mutate its input.
+	if unsorted[0].cert != root {
+		t.Errorf("sortChain mutated its input slice")
+	}
+}
+
+func TestAnchorsOnly(t *testing.T) {
+	root, rootKey := testCA(t, "root")
+	leaf := testLeafSignedBy(t, "leaf", root, rootKey, time.Now().Add(time.Hour))
+
+	anchors := anchorsOnly(sortChain([]certInfo{{cert: leaf}, {cert: root}}))
+	if len(anchors) != 1 || anchors[0].cert != root {
+		t.Errorf("anchorsOnly() = %v, want only the root", anchors)
+	}
+}
+
+func TestVerifyCert(t *testing.T) {
+	root, rootKey := testCA(t, "root")
+
+	t.Run("chain mode trusts a leaf signed by a root already in Roots", func(t *testing.T) {
+		leaf := testLeafSignedBy(t, "leaf", root, rootKey, time.Now().Add(time.Hour))
+		roots := x509.NewCertPool()
+		roots.AddCert(root)
+		if err := verifyCert(leaf, v1.VerifyChain, roots, x509.NewCertPool()); err != nil {
+			t.Errorf("verifyCert() = %v, want nil", err)
+		}
+	})
+
+	t.Run("chain mode rejects a leaf when its root is not trusted", func(t *testing.T) {
+		leaf := testLeafSignedBy(t, "leaf", root, rootKey, time.Now().Add(time.Hour))
+		if err := verifyCert(leaf, v1.VerifyChain, x509.NewCertPool(), x509.NewCertPool()); err == nil {
+			t.Errorf("verifyCert() = nil, want an error for an untrusted root")
+		}
+	})
+
+	t.Run("strict mode rejects an expired certificate even with a trusted root", func(t *testing.T) {
+		leaf := testLeafSignedBy(t, "leaf", root, rootKey, time.Now().Add(-time.Hour))
+		roots := x509.NewCertPool()
+		roots.AddCert(root)
+		if err := verifyCert(leaf, v1.VerifyStrict, roots, x509.NewCertPool()); err == nil {
+			t.Errorf("verifyCert() = nil, want an error for an expired certificate")
+		}
+	})
+
+	t.Run("strict mode rejects a CA certificate without KeyUsageCertSign", func(t *testing.T) {
+		weakCA := *root
+		weakCA.KeyUsage = x509.KeyUsageDigitalSignature
+		roots := x509.NewCertPool()
+		roots.AddCert(root)
+		if err := verifyCert(&weakCA, v1.VerifyStrict, roots, x509.NewCertPool()); err == nil {
+			t.Errorf("verifyCert() = nil, want an error for a CA without KeyUsageCertSign")
+		}
+	})
+}
+
+func TestVerifyCertsRejectsSelfSignedSourceRoot(t *testing.T) {
+	// An "attacker" root and a leaf it signs, both pulled from the Secrets being
+	// verified rather than from watcher.Spec.TrustAnchorConfigMaps. Nothing here is
+	// trusted externally, so chain mode must reject both: trusting a self-signed cert
+	// merely because it showed up in the untrusted data being verified would let any
+	// matched Secret smuggle an arbitrary CA+leaf pair through verification.
+	attackerRoot, attackerKey := testCA(t, "attacker-root")
+	attackerLeaf := testLeafSignedBy(t, "attacker-leaf", attackerRoot, attackerKey, time.Now().Add(time.Hour))
+
+	watcher := &v1.TLSSecretWatcher{Spec: v1.TLSSecretWatcherSpec{Verify: v1.VerifyChain}}
+	certs := []certInfo{{cert: attackerRoot}, {cert: attackerLeaf}}
+
+	r := &TLSSecretWatcherReconciler{}
+	verified, err := r.verifyCerts(context.Background(), watcher, certs)
+	if err != nil {
+		t.Fatalf("verifyCerts() error = %v", err)
+	}
+	if len(verified) != 0 {
+		t.Errorf("len(verified) = %d, want 0 (no externally trusted root was configured)", len(verified))
+	}
+}
+
+func TestCertSetHash(t *testing.T) {
+	root, _ := testCA(t, "root")
+	other, _ := testCA(t, "other")
+
+	if certSetHash([]*x509.Certificate{root, other}) != certSetHash([]*x509.Certificate{other, root}) {
+		t.Errorf("certSetHash() is not order-independent")
+	}
+	if certSetHash([]*x509.Certificate{root}) == certSetHash([]*x509.Certificate{root, other}) {
+		t.Errorf("certSetHash() did not change when the cert set changed")
+	}
+}
+
+// gaugeVecLabelSets returns one sorted "name=value,..." string per series currently
+// held by vec, collected directly from the vector so checking for a series' absence
+// never itself creates that series (unlike WithLabelValues/GetMetricWith).
+func gaugeVecLabelSets(t *testing.T, vec *prometheus.GaugeVec) map[string]bool {
+	t.Helper()
+	ch := make(chan prometheus.Metric, 64)
+	vec.Collect(ch)
+	close(ch)
+
+	sets := map[string]bool{}
+	for m := range ch {
+		var metric dto.Metric
+		if err := m.Write(&metric); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		parts := make([]string, 0, len(metric.Label))
+		for _, l := range metric.Label {
+			parts = append(parts, l.GetName()+"="+l.GetValue())
+		}
+		sort.Strings(parts)
+		sets[strings.Join(parts, ",")] = true
+	}
+	return sets
+}
+
+func TestReportCertStatusPrunesStaleMetricSeries(t *testing.T) {
+	certA, _ := testCA(t, "prune-series-a")
+	certB, _ := testCA(t, "prune-series-b")
+	watcher := &v1.TLSSecretWatcher{}
+	watcher.Name = "prune-series-watcher"
+	r := &TLSSecretWatcherReconciler{}
+
+	r.reportCertStatus(watcher, []certInfo{
+		{secretName: "s", secretNamespace: "ns", cert: certA},
+		{secretName: "s", secretNamespace: "ns", cert: certB},
+	})
+
+	sets := gaugeVecLabelSets(t, metrics.CertificateNotAfterSeconds)
+	found := false
+	for key := range sets {
+		if strings.Contains(key, certA.Subject.String()) {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a series for certA's subject after the first reconcile, got %v", sets)
+	}
+
+	// certA rotates out of the source set on the next reconcile; only certB remains.
+	r.reportCertStatus(watcher, []certInfo{
+		{secretName: "s", secretNamespace: "ns", cert: certB},
+	})
+
+	sets = gaugeVecLabelSets(t, metrics.CertificateNotAfterSeconds)
+	for key := range sets {
+		if strings.Contains(key, certA.Subject.String()) {
+			t.Errorf("stale series for certA's subject survived a reconcile that dropped it: %v", sets)
+		}
+	}
+}
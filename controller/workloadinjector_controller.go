@@ -0,0 +1,433 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1 "pottmeier.de/api/v1"
+)
+
+const (
+	// injectCAAnnotation, set on a Deployment/StatefulSet/DaemonSet, names the
+	// TLSSecretWatcher whose trust bundle ConfigMap for this workload's namespace should
+	// be projected into the pod template. Removing the annotation reverts the injection
+	// on the next reconcile.
+	injectCAAnnotation = "de.pottmeier.converter/inject-ca"
+
+	// injectCAPathAnnotation overrides the mount path of the trust bundle file.
+	// Defaults to defaultInjectCAPath.
+	injectCAPathAnnotation = "de.pottmeier.converter/inject-ca-path"
+
+	// injectCAEnvAnnotation, set to "true", additionally sets SSL_CERT_FILE and
+	// NODE_EXTRA_CA_CERTS on every container to the mount path.
+	injectCAEnvAnnotation = "de.pottmeier.converter/inject-ca-env"
+
+	// caBundleHashAnnotation is stamped onto the pod template with a hash of the
+	// trust bundle ConfigMap's data, so a content change revs the template and
+	// triggers a rollout even though the mounted ConfigMap itself updates in place.
+	caBundleHashAnnotation = "de.pottmeier.converter/ca-bundle-hash"
+
+	defaultInjectCAPath = "/etc/ssl/certs/ca-bundle.crt"
+	injectedVolumeName  = "converter-ca-bundle"
+	caBundleDataKey     = "ca.crt"
+)
+
+// watcherNameFromOwner returns the name of the TLSSecretWatcher that owns cm, identified
+// by OwnerReference rather than by name, since a Destination.ConfigMapName can override
+// the default "<watcher-name>-ca" naming.
+func watcherNameFromOwner(cm *corev1.ConfigMap) (string, bool) {
+	for _, ref := range cm.OwnerReferences {
+		if ref.Kind == "TLSSecretWatcher" {
+			return ref.Name, true
+		}
+	}
+	return "", false
+}
+
+// applyCAInjection reconciles template against the workload's inject-ca annotations and
+// its trust bundle ConfigMap (nil if not found yet), returning whether template was
+// modified so callers only write back the workload when something actually changed.
+func applyCAInjection(annotations map[string]string, template *corev1.PodTemplateSpec, cm *corev1.ConfigMap) bool {
+	watcherName := annotations[injectCAAnnotation]
+	if watcherName == "" || cm == nil {
+		return removeCAInjection(template)
+	}
+
+	path := annotations[injectCAPathAnnotation]
+	if path == "" {
+		path = defaultInjectCAPath
+	}
+	injectEnv := annotations[injectCAEnvAnnotation] == "true"
+
+	changed := false
+
+	if idx := volumeIndex(template.Spec.Volumes, injectedVolumeName); idx == -1 {
+		template.Spec.Volumes = append(template.Spec.Volumes, caBundleVolume(cm.Name))
+		changed = true
+	} else if template.Spec.Volumes[idx].ConfigMap == nil || template.Spec.Volumes[idx].ConfigMap.Name != cm.Name {
+		template.Spec.Volumes[idx] = caBundleVolume(cm.Name)
+		changed = true
+	}
+
+	for i := range template.Spec.Containers {
+		container := &template.Spec.Containers[i]
+		if addMount(container, path) {
+			changed = true
+		}
+		if injectEnv && addEnv(container, path) {
+			changed = true
+		} else if !injectEnv && removeEnv(container) {
+			changed = true
+		}
+	}
+
+	hash := configMapHash(cm)
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	if template.Annotations[caBundleHashAnnotation] != hash {
+		template.Annotations[caBundleHashAnnotation] = hash
+		changed = true
+	}
+
+	return changed
+}
+
+// removeCAInjection strips any previously-injected volume, mount, env vars, and hash
+// annotation from template. Used when the workload's inject-ca annotation is absent or
+// its trust bundle ConfigMap is not found.
+func removeCAInjection(template *corev1.PodTemplateSpec) bool {
+	changed := false
+
+	if hasVolume(template.Spec.Volumes, injectedVolumeName) {
+		template.Spec.Volumes = removeVolume(template.Spec.Volumes, injectedVolumeName)
+		changed = true
+	}
+
+	for i := range template.Spec.Containers {
+		container := &template.Spec.Containers[i]
+		if removeMount(container) {
+			changed = true
+		}
+		if removeEnv(container) {
+			changed = true
+		}
+	}
+
+	if _, ok := template.Annotations[caBundleHashAnnotation]; ok {
+		delete(template.Annotations, caBundleHashAnnotation)
+		changed = true
+	}
+
+	return changed
+}
+
+func hasVolume(volumes []corev1.Volume, name string) bool {
+	return volumeIndex(volumes, name) != -1
+}
+
+func volumeIndex(volumes []corev1.Volume, name string) int {
+	for i, v := range volumes {
+		if v.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func caBundleVolume(configMapName string) corev1.Volume {
+	return corev1.Volume{
+		Name: injectedVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+			},
+		},
+	}
+}
+
+func removeVolume(volumes []corev1.Volume, name string) []corev1.Volume {
+	out := make([]corev1.Volume, 0, len(volumes))
+	for _, v := range volumes {
+		if v.Name != name {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func addMount(container *corev1.Container, path string) bool {
+	for i, m := range container.VolumeMounts {
+		if m.Name == injectedVolumeName {
+			if m.MountPath == path && m.SubPath == caBundleDataKey {
+				return false
+			}
+			container.VolumeMounts[i].MountPath = path
+			container.VolumeMounts[i].SubPath = caBundleDataKey
+			return true
+		}
+	}
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      injectedVolumeName,
+		MountPath: path,
+		SubPath:   caBundleDataKey,
+		ReadOnly:  true,
+	})
+	return true
+}
+
+func removeMount(container *corev1.Container) bool {
+	for i, m := range container.VolumeMounts {
+		if m.Name == injectedVolumeName {
+			container.VolumeMounts = append(container.VolumeMounts[:i], container.VolumeMounts[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+var caInjectedEnvVars = []string{"SSL_CERT_FILE", "NODE_EXTRA_CA_CERTS"}
+
+func addEnv(container *corev1.Container, path string) bool {
+	changed := false
+	for _, name := range caInjectedEnvVars {
+		if setEnvVar(container, name, path) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func setEnvVar(container *corev1.Container, name, value string) bool {
+	for i, e := range container.Env {
+		if e.Name == name {
+			if e.Value == value {
+				return false
+			}
+			container.Env[i].Value = value
+			return true
+		}
+	}
+	container.Env = append(container.Env, corev1.EnvVar{Name: name, Value: value})
+	return true
+}
+
+func removeEnv(container *corev1.Container) bool {
+	changed := false
+	for _, name := range caInjectedEnvVars {
+		for i, e := range container.Env {
+			if e.Name == name {
+				container.Env = append(container.Env[:i], container.Env[i+1:]...)
+				changed = true
+				break
+			}
+		}
+	}
+	return changed
+}
+
+// configMapHash fingerprints a ConfigMap's data independent of key iteration order.
+func configMapHash(cm *corev1.ConfigMap) string {
+	keys := make([]string, 0, len(cm.Data))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte(cm.Data[k]))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// getTrustBundleConfigMap resolves the trust bundle ConfigMap that the TLSSecretWatcher
+// named in the inject-ca annotation actually produces for namespace, honoring a
+// Destination's custom ConfigMapName instead of assuming the default "<watcher-name>-ca"
+// naming. It returns nil, nil (not an error) when the watcher, a Destination targeting
+// namespace, or the ConfigMap itself do not exist yet.
+func getTrustBundleConfigMap(ctx context.Context, r client.Client, namespace string, annotations map[string]string) (*corev1.ConfigMap, error) {
+	watcherName := annotations[injectCAAnnotation]
+	if watcherName == "" {
+		return nil, nil
+	}
+
+	var watcher v1.TLSSecretWatcher
+	if err := r.Get(ctx, client.ObjectKey{Name: watcherName}, &watcher); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	configMapName := ""
+	for _, dest := range watcher.Spec.Destinations {
+		if containsString(dest.Namespaces, namespace) {
+			configMapName = destinationConfigMapName(watcher, dest)
+			break
+		}
+	}
+	if configMapName == "" {
+		return nil, nil
+	}
+
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: configMapName}, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &cm, nil
+}
+
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;update;patch
+
+// workloadKind adapts one apps/v1 workload type (Deployment, StatefulSet, DaemonSet) to
+// the CA-injection logic shared by all three, so WorkloadCAInjectorReconciler need not be
+// duplicated per kind.
+type workloadKind interface {
+	name() string
+	newObject() client.Object
+	newList() client.ObjectList
+	podTemplate(obj client.Object) *corev1.PodTemplateSpec
+	forEach(list client.ObjectList, fn func(client.Object))
+}
+
+type deploymentKind struct{}
+
+func (deploymentKind) name() string               { return "Deployment" }
+func (deploymentKind) newObject() client.Object   { return &appsv1.Deployment{} }
+func (deploymentKind) newList() client.ObjectList { return &appsv1.DeploymentList{} }
+func (deploymentKind) podTemplate(obj client.Object) *corev1.PodTemplateSpec {
+	return &obj.(*appsv1.Deployment).Spec.Template
+}
+func (deploymentKind) forEach(list client.ObjectList, fn func(client.Object)) {
+	items := list.(*appsv1.DeploymentList).Items
+	for i := range items {
+		fn(&items[i])
+	}
+}
+
+type statefulSetKind struct{}
+
+func (statefulSetKind) name() string               { return "StatefulSet" }
+func (statefulSetKind) newObject() client.Object   { return &appsv1.StatefulSet{} }
+func (statefulSetKind) newList() client.ObjectList { return &appsv1.StatefulSetList{} }
+func (statefulSetKind) podTemplate(obj client.Object) *corev1.PodTemplateSpec {
+	return &obj.(*appsv1.StatefulSet).Spec.Template
+}
+func (statefulSetKind) forEach(list client.ObjectList, fn func(client.Object)) {
+	items := list.(*appsv1.StatefulSetList).Items
+	for i := range items {
+		fn(&items[i])
+	}
+}
+
+type daemonSetKind struct{}
+
+func (daemonSetKind) name() string               { return "DaemonSet" }
+func (daemonSetKind) newObject() client.Object   { return &appsv1.DaemonSet{} }
+func (daemonSetKind) newList() client.ObjectList { return &appsv1.DaemonSetList{} }
+func (daemonSetKind) podTemplate(obj client.Object) *corev1.PodTemplateSpec {
+	return &obj.(*appsv1.DaemonSet).Spec.Template
+}
+func (daemonSetKind) forEach(list client.ObjectList, fn func(client.Object)) {
+	items := list.(*appsv1.DaemonSetList).Items
+	for i := range items {
+		fn(&items[i])
+	}
+}
+
+// WorkloadCAInjectorReconciler projects a TLSSecretWatcher's trust bundle ConfigMap into
+// the pod template of every Deployment, StatefulSet, or DaemonSet carrying the
+// inject-ca annotation. One instance, constructed via the NewXCAInjectorReconciler
+// functions below, is registered per workload kind.
+type WorkloadCAInjectorReconciler struct {
+	client.Client
+	kind workloadKind
+}
+
+// NewDeploymentCAInjectorReconciler returns a WorkloadCAInjectorReconciler for Deployments.
+func NewDeploymentCAInjectorReconciler(c client.Client) *WorkloadCAInjectorReconciler {
+	return &WorkloadCAInjectorReconciler{Client: c, kind: deploymentKind{}}
+}
+
+// NewStatefulSetCAInjectorReconciler returns a WorkloadCAInjectorReconciler for StatefulSets.
+func NewStatefulSetCAInjectorReconciler(c client.Client) *WorkloadCAInjectorReconciler {
+	return &WorkloadCAInjectorReconciler{Client: c, kind: statefulSetKind{}}
+}
+
+// NewDaemonSetCAInjectorReconciler returns a WorkloadCAInjectorReconciler for DaemonSets.
+func NewDaemonSetCAInjectorReconciler(c client.Client) *WorkloadCAInjectorReconciler {
+	return &WorkloadCAInjectorReconciler{Client: c, kind: daemonSetKind{}}
+}
+
+func (r *WorkloadCAInjectorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	obj := r.kind.newObject()
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	cm, err := getTrustBundleConfigMap(ctx, r.Client, obj.GetNamespace(), obj.GetAnnotations())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !applyCAInjection(obj.GetAnnotations(), r.kind.podTemplate(obj), cm) {
+		return ctrl.Result{}, nil
+	}
+
+	logf.FromContext(ctx).Info("injecting CA bundle", "kind", r.kind.name(), "name", obj.GetName(), "namespace", obj.GetNamespace())
+	return ctrl.Result{}, r.Update(ctx, obj)
+}
+
+func (r *WorkloadCAInjectorReconciler) mapConfigMapToWorkloads(ctx context.Context, obj client.Object) []reconcile.Request {
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return nil
+	}
+	watcherName, ok := watcherNameFromOwner(cm)
+	if !ok {
+		return nil
+	}
+
+	list := r.kind.newList()
+	if err := r.List(ctx, list, client.InNamespace(cm.Namespace)); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list workloads", "kind", r.kind.name())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	r.kind.forEach(list, func(workload client.Object) {
+		if workload.GetAnnotations()[injectCAAnnotation] == watcherName {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(workload)})
+		}
+	})
+	return requests
+}
+
+func (r *WorkloadCAInjectorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(r.kind.newObject()).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.mapConfigMapToWorkloads)).
+		Complete(r)
+}
@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testConfigMap(name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Data:       data,
+	}
+}
+
+func testTemplate() *corev1.PodTemplateSpec {
+	return &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}},
+		},
+	}
+}
+
+func TestApplyCAInjectionAddsVolumeMountAndHash(t *testing.T) {
+	cm := testConfigMap("watcher-ca", map[string]string{"ca.crt": "pem-data"})
+	template := testTemplate()
+	annotations := map[string]string{injectCAAnnotation: "watcher"}
+
+	if changed := applyCAInjection(annotations, template, cm); !changed {
+		t.Fatalf("applyCAInjection() = false, want true on first injection")
+	}
+
+	if !hasVolume(template.Spec.Volumes, injectedVolumeName) {
+		t.Errorf("expected injected volume %q to be present", injectedVolumeName)
+	}
+	mount := template.Spec.Containers[0].VolumeMounts[0]
+	if mount.MountPath != defaultInjectCAPath || mount.SubPath != caBundleDataKey {
+		t.Errorf("mount = %+v, want path %s subPath %s", mount, defaultInjectCAPath, caBundleDataKey)
+	}
+	if len(template.Spec.Containers[0].Env) != 0 {
+		t.Errorf("expected no env vars set without inject-ca-env, got %v", template.Spec.Containers[0].Env)
+	}
+	if template.Annotations[caBundleHashAnnotation] != configMapHash(cm) {
+		t.Errorf("hash annotation = %q, want %q", template.Annotations[caBundleHashAnnotation], configMapHash(cm))
+	}
+}
+
+func TestApplyCAInjectionSetsEnvWhenRequested(t *testing.T) {
+	cm := testConfigMap("watcher-ca", map[string]string{"ca.crt": "pem-data"})
+	template := testTemplate()
+	annotations := map[string]string{
+		injectCAAnnotation:    "watcher",
+		injectCAEnvAnnotation: "true",
+	}
+
+	applyCAInjection(annotations, template, cm)
+
+	env := template.Spec.Containers[0].Env
+	want := map[string]string{"SSL_CERT_FILE": defaultInjectCAPath, "NODE_EXTRA_CA_CERTS": defaultInjectCAPath}
+	if len(env) != len(want) {
+		t.Fatalf("len(env) = %d, want %d", len(env), len(want))
+	}
+	for _, e := range env {
+		if want[e.Name] != e.Value {
+			t.Errorf("env %s = %q, want %q", e.Name, e.Value, want[e.Name])
+		}
+	}
+}
+
+func TestApplyCAInjectionIsIdempotentWhenUnchanged(t *testing.T) {
+	cm := testConfigMap("watcher-ca", map[string]string{"ca.crt": "pem-data"})
+	template := testTemplate()
+	annotations := map[string]string{injectCAAnnotation: "watcher"}
+
+	applyCAInjection(annotations, template, cm)
+	if changed := applyCAInjection(annotations, template, cm); changed {
+		t.Errorf("applyCAInjection() = true on a second call with the same ConfigMap, want false")
+	}
+}
+
+func TestApplyCAInjectionSwitchesConfigMapSource(t *testing.T) {
+	template := testTemplate()
+	annotations := map[string]string{injectCAAnnotation: "watcher"}
+
+	applyCAInjection(annotations, template, testConfigMap("watcher-ca", map[string]string{"ca.crt": "old"}))
+
+	newCM := testConfigMap("watcher-ca-custom", map[string]string{"ca.crt": "new"})
+	if changed := applyCAInjection(annotations, template, newCM); !changed {
+		t.Fatalf("applyCAInjection() = false, want true when the target ConfigMap name changes")
+	}
+
+	idx := volumeIndex(template.Spec.Volumes, injectedVolumeName)
+	if idx == -1 {
+		t.Fatalf("injected volume is missing after switching ConfigMaps")
+	}
+	if template.Spec.Volumes[idx].ConfigMap.Name != "watcher-ca-custom" {
+		t.Errorf("volume ConfigMap name = %q, want %q", template.Spec.Volumes[idx].ConfigMap.Name, "watcher-ca-custom")
+	}
+}
+
+func TestApplyCAInjectionRemovesInjectionWhenAnnotationCleared(t *testing.T) {
+	cm := testConfigMap("watcher-ca", map[string]string{"ca.crt": "pem-data"})
+	template := testTemplate()
+	annotations := map[string]string{
+		injectCAAnnotation:    "watcher",
+		injectCAEnvAnnotation: "true",
+	}
+	applyCAInjection(annotations, template, cm)
+
+	if changed := applyCAInjection(map[string]string{}, template, cm); !changed {
+		t.Fatalf("applyCAInjection() = false, want true when the inject-ca annotation is removed")
+	}
+	if hasVolume(template.Spec.Volumes, injectedVolumeName) {
+		t.Errorf("expected injected volume to be removed")
+	}
+	if len(template.Spec.Containers[0].VolumeMounts) != 0 {
+		t.Errorf("expected volume mount to be removed, got %v", template.Spec.Containers[0].VolumeMounts)
+	}
+	if len(template.Spec.Containers[0].Env) != 0 {
+		t.Errorf("expected env vars to be removed, got %v", template.Spec.Containers[0].Env)
+	}
+	if _, ok := template.Annotations[caBundleHashAnnotation]; ok {
+		t.Errorf("expected hash annotation to be removed")
+	}
+}
+
+func TestApplyCAInjectionNoOpWithoutAnnotationOrConfigMap(t *testing.T) {
+	template := testTemplate()
+	if changed := applyCAInjection(map[string]string{}, template, nil); changed {
+		t.Errorf("applyCAInjection() = true, want false when nothing was ever injected")
+	}
+}
+
+func TestConfigMapHashStableAcrossKeyOrder(t *testing.T) {
+	a := testConfigMap("cm", map[string]string{"ca.crt": "x", "extra.crt": "y"})
+	b := testConfigMap("cm", map[string]string{"extra.crt": "y", "ca.crt": "x"})
+	if configMapHash(a) != configMapHash(b) {
+		t.Errorf("configMapHash() is not independent of map iteration order")
+	}
+
+	c := testConfigMap("cm", map[string]string{"ca.crt": "different"})
+	if configMapHash(a) == configMapHash(c) {
+		t.Errorf("configMapHash() did not change when the data changed")
+	}
+}
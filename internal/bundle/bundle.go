@@ -0,0 +1,73 @@
+// Package bundle encodes a set of trust-anchor certificates into the binary keystore
+// formats consumed by JVM and .NET workloads.
+package bundle
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+	pkcs12 "software.sslmate.com/src/go-pkcs12"
+
+	v1 "pottmeier.de/api/v1"
+)
+
+// Encoder produces a binary keystore from a set of trust-anchor certificates.
+type Encoder interface {
+	// DefaultKey is the Secret data key this encoder writes its output under when a
+	// FormatOutput does not set one explicitly.
+	DefaultKey() string
+
+	Encode(certs []*x509.Certificate, password string) ([]byte, error)
+}
+
+// EncoderFor returns the Encoder for a BundleFormat, or nil if the format is
+// unsupported.
+func EncoderFor(format v1.BundleFormat) Encoder {
+	switch format {
+	case v1.BundleFormatPKCS12:
+		return pkcs12Encoder{}
+	case v1.BundleFormatJKS:
+		return jksEncoder{}
+	default:
+		return nil
+	}
+}
+
+type pkcs12Encoder struct{}
+
+func (pkcs12Encoder) DefaultKey() string { return "truststore.p12" }
+
+func (pkcs12Encoder) Encode(certs []*x509.Certificate, password string) ([]byte, error) {
+	return pkcs12.EncodeTrustStore(rand.Reader, certs, password)
+}
+
+type jksEncoder struct{}
+
+func (jksEncoder) DefaultKey() string { return "cacerts.jks" }
+
+func (jksEncoder) Encode(certs []*x509.Certificate, password string) ([]byte, error) {
+	ks := keystore.New()
+	for i, cert := range certs {
+		alias := fmt.Sprintf("ca-%d", i)
+		entry := keystore.TrustedCertificateEntry{
+			CreationTime: time.Now(),
+			Certificate: keystore.Certificate{
+				Type:    "X509",
+				Content: cert.Raw,
+			},
+		}
+		if err := ks.SetTrustedCertificateEntry(alias, entry); err != nil {
+			return nil, fmt.Errorf("adding certificate %s to keystore: %w", alias, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := ks.Store(&buf, []byte(password)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
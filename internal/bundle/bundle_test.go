@@ -0,0 +1,110 @@
+package bundle
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	keystore "github.com/pavlo-v-chernykh/keystore-go/v4"
+	pkcs12 "software.sslmate.com/src/go-pkcs12"
+
+	v1 "pottmeier.de/api/v1"
+)
+
+func testCA(t *testing.T, commonName string) *x509.Certificate {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+	return cert
+}
+
+func TestEncoderForUnsupportedFormat(t *testing.T) {
+	if enc := EncoderFor(v1.BundleFormat("unknown")); enc != nil {
+		t.Errorf("EncoderFor(unknown) = %v, want nil", enc)
+	}
+}
+
+func TestPKCS12EncodeRoundTrip(t *testing.T) {
+	certs := []*x509.Certificate{testCA(t, "root-a"), testCA(t, "root-b")}
+
+	enc := EncoderFor(v1.BundleFormatPKCS12)
+	if enc == nil {
+		t.Fatal("EncoderFor(pkcs12) = nil")
+	}
+	if enc.DefaultKey() != "truststore.p12" {
+		t.Errorf("DefaultKey() = %q, want truststore.p12", enc.DefaultKey())
+	}
+
+	data, err := enc.Encode(certs, "changeit")
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	decoded, err := pkcs12.DecodeTrustStore(data, "changeit")
+	if err != nil {
+		t.Fatalf("DecodeTrustStore() error = %v", err)
+	}
+	if len(decoded) != len(certs) {
+		t.Fatalf("len(decoded) = %d, want %d", len(decoded), len(certs))
+	}
+}
+
+func TestJKSEncodeRoundTrip(t *testing.T) {
+	certs := []*x509.Certificate{testCA(t, "root-a"), testCA(t, "root-b")}
+
+	enc := EncoderFor(v1.BundleFormatJKS)
+	if enc == nil {
+		t.Fatal("EncoderFor(jks) = nil")
+	}
+	if enc.DefaultKey() != "cacerts.jks" {
+		t.Errorf("DefaultKey() = %q, want cacerts.jks", enc.DefaultKey())
+	}
+
+	data, err := enc.Encode(certs, "changeit")
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	ks := keystore.New()
+	if err := ks.Load(bytes.NewReader(data), []byte("changeit")); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(ks.Aliases()) != len(certs) {
+		t.Fatalf("len(aliases) = %d, want %d", len(ks.Aliases()), len(certs))
+	}
+	for i, cert := range certs {
+		alias := fmt.Sprintf("ca-%d", i)
+		entry, err := ks.GetTrustedCertificateEntry(alias)
+		if err != nil {
+			t.Fatalf("GetTrustedCertificateEntry(%s) error = %v", alias, err)
+		}
+		if !bytes.Equal(entry.Certificate.Content, cert.Raw) {
+			t.Errorf("alias %s certificate content mismatch", alias)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Package metrics holds the Prometheus collectors exposed by the converter operator.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// CertificateNotAfterSeconds reports the Unix timestamp at which a source
+	// certificate expires.
+	CertificateNotAfterSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "converter_certificate_not_after_seconds",
+			Help: "Unix timestamp (seconds) at which the certificate's NotAfter is reached.",
+		},
+		[]string{"secret", "namespace", "subject", "serial", "issuer"},
+	)
+
+	// CertificateExpiringSoon is 1 when a certificate is within its watcher's
+	// ExpiryWarningDays window, 0 otherwise.
+	CertificateExpiringSoon = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "converter_certificate_expiring_soon",
+			Help: "1 if the certificate is within ExpiryWarningDays of expiry, 0 otherwise.",
+		},
+		[]string{"secret", "namespace", "subject", "serial", "issuer"},
+	)
+
+	// ReconcileTotal counts TLSSecretWatcher reconciliations by outcome.
+	ReconcileTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "converter_reconcile_total",
+			Help: "Total number of TLSSecretWatcher reconciliations by outcome.",
+		},
+		[]string{"watcher", "result"},
+	)
+
+	// VerificationFailuresTotal counts source certificates skipped because they failed
+	// Spec.Verify.
+	VerificationFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "converter_verification_failures_total",
+			Help: "Total number of source certificates skipped because they failed chain verification.",
+		},
+		[]string{"watcher", "secret", "namespace"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		CertificateNotAfterSeconds,
+		CertificateExpiringSoon,
+		ReconcileTotal,
+		VerificationFailuresTotal,
+	)
+}